@@ -0,0 +1,142 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-09-01/insights"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+func dataSourceArmMonitorMetricDefinition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMonitorMetricDefinitionRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"metric_namespace": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"metrics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"unit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"primary_aggregation_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"supported_aggregation_types": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						// the Metric Definitions API only returns the names of the dimensions a
+						// metric can be split by - it never returns the set of values a dimension
+						// can take, so there's no `values` field to expose here.
+						"dimensions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmMonitorMetricDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).monitorMetricDefinitionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceID := d.Get("resource_id").(string)
+	metricNamespace := d.Get("metric_namespace").(string)
+
+	resp, err := client.List(ctx, resourceID, metricNamespace)
+	if err != nil {
+		return fmt.Errorf("Error listing Metric Definitions for %q (Namespace %q): %+v", resourceID, metricNamespace, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-metricDefinitions-%s", resourceID, metricNamespace))
+
+	d.Set("resource_id", resourceID)
+	d.Set("metric_namespace", metricNamespace)
+
+	if err := d.Set("metrics", flattenMonitorMetricDefinitions(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `metrics`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenMonitorMetricDefinitions(input *[]insights.MetricDefinition) []interface{} {
+	result := make([]interface{}, 0)
+	if input == nil {
+		return result
+	}
+
+	for _, metric := range *input {
+		v := make(map[string]interface{})
+
+		if metric.Name != nil && metric.Name.Value != nil {
+			v["name"] = *metric.Name.Value
+		}
+		v["unit"] = string(metric.Unit)
+		v["primary_aggregation_type"] = string(metric.PrimaryAggregationType)
+
+		supported := make([]interface{}, 0)
+		if metric.SupportedAggregationTypes != nil {
+			for _, aggregationType := range *metric.SupportedAggregationTypes {
+				supported = append(supported, string(aggregationType))
+			}
+		}
+		v["supported_aggregation_types"] = supported
+
+		dimensions := make([]interface{}, 0)
+		if metric.Dimensions != nil {
+			for _, dimension := range *metric.Dimensions {
+				dVal := make(map[string]interface{})
+				if dimension.Value != nil {
+					dVal["name"] = *dimension.Value
+				}
+				dimensions = append(dimensions, dVal)
+			}
+		}
+		v["dimensions"] = dimensions
+
+		result = append(result, v)
+	}
+
+	return result
+}
@@ -0,0 +1,104 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-09-01/insights"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMonitorMetricNamespace() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMonitorMetricNamespaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"namespaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"classification": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"fully_qualified_namespace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmMonitorMetricNamespaceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).monitorMetricNamespacesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceID := d.Get("resource_id").(string)
+
+	var startTime *string
+	if v, ok := d.GetOk("start_time"); ok {
+		startTime = utils.String(v.(string))
+	}
+
+	resp, err := client.List(ctx, resourceID, startTime)
+	if err != nil {
+		return fmt.Errorf("Error listing Metric Namespaces for %q: %+v", resourceID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-metricNamespaces", resourceID))
+
+	d.Set("resource_id", resourceID)
+
+	if err := d.Set("namespaces", flattenMonitorMetricNamespaces(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `namespaces`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenMonitorMetricNamespaces(input *[]insights.MetricNamespace) []interface{} {
+	result := make([]interface{}, 0)
+	if input == nil {
+		return result
+	}
+
+	for _, ns := range *input {
+		v := make(map[string]interface{})
+
+		if ns.Name != nil {
+			v["name"] = *ns.Name
+		}
+		v["classification"] = string(ns.Classification)
+		if props := ns.Properties; props != nil && props.MetricNamespaceName != nil {
+			v["fully_qualified_namespace"] = *props.MetricNamespaceName
+		}
+
+		result = append(result, v)
+	}
+
+	return result
+}
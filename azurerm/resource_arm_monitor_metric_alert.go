@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-09-01/insights"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -14,6 +19,34 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func monitorMetricAlertDimensionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"operator": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
 func resourceArmMonitorMetricAlert() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmMonitorMetricAlertCreateOrUpdate,
@@ -35,14 +68,10 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 
 			"resource_group_name": resourceGroupNameSchema(),
 
-			// TODO: Multiple resource IDs (Remove MaxItems) support is missing in SDK
-			// Issue to track: https://github.com/Azure/azure-sdk-for-go/issues/2920
-			// But to prevent potential state migration in the future, let's stick to use Set now
 			"scopes": {
 				Type:     schema.TypeSet,
 				Required: true,
 				MinItems: 1,
-				MaxItems: 1,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
 					ValidateFunc: azure.ValidateResourceID,
@@ -50,10 +79,26 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 				Set: schema.HashString,
 			},
 
+			// required when more than one scope is supplied or when `dynamic_criteria` is used,
+			// since the criteria must then be serialized as a `MetricAlertMultipleResourceMultipleMetricCriteria`
+			"target_resource_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"target_resource_location": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    azureRMNormalizeLocation,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
 			"criteria": {
-				Type:     schema.TypeList,
-				Required: true,
-				MinItems: 1,
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				ConflictsWith: []string{"dynamic_criteria", "webtest_location_availability_criteria"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"metric_namespace": {
@@ -94,31 +139,109 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 							Type:     schema.TypeFloat,
 							Required: true,
 						},
-						"dimension": {
+						"dimension": monitorMetricAlertDimensionSchema(),
+					},
+				},
+			},
+
+			"dynamic_criteria": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				ConflictsWith: []string{"criteria", "webtest_location_availability_criteria"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metric_namespace": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"metric_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"aggregation": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"Average",
+								"Minimum",
+								"Maximum",
+								"Total",
+							}, true),
+							DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"GreaterThan",
+								"LessThan",
+								"GreaterOrLessThan",
+							}, true),
+							DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+						},
+						"alert_sensitivity": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"Low",
+								"Medium",
+								"High",
+							}, true),
+							DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+						},
+						"evaluation_failing_periods": {
 							Type:     schema.TypeList,
 							Optional: true,
+							MaxItems: 1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:         schema.TypeString,
+									"number_of_evaluation_periods": {
+										Type:         schema.TypeInt,
 										Required:     true,
-										ValidateFunc: validation.NoZeroValues,
+										ValidateFunc: validation.IntAtLeast(1),
 									},
-									"values": {
-										Type:     schema.TypeList,
-										Required: true,
-										MinItems: 1,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"operator": {
-										Type:     schema.TypeString,
-										Optional: true,
+									"min_failing_periods_to_alert": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
 									},
 								},
 							},
 						},
+						"ignore_data_before": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"dimension": monitorMetricAlertDimensionSchema(),
+					},
+				},
+			},
+
+			"webtest_location_availability_criteria": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"criteria", "dynamic_criteria"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"web_test_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+						"component_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+						"failed_location_count": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
 					},
 				},
 			},
@@ -140,6 +263,68 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+
+						"notification": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"documentation_markdown": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"runbook_url": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"labels": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									// intentionally Optional, not Computed, and never populated from the
+									// server on Read (see flattenMonitorMetricAlertAction): this stays a
+									// pure passthrough of what the user configured. `expandMonitorMetricAlertAction`
+									// falls back to `criteriaFingerprint` whenever it's unset - if the
+									// fallback value were ever written back into this field, `d.Get` would
+									// return that stale value instead of "" on the next Update and the
+									// fallback would never fire again once criteria changed.
+									"mutation_id": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.NoZeroValues,
+									},
+								},
+							},
+						},
+
+						"action_condition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									// -1 (the default) means "not set" - 0 is itself a valid severity (Critical),
+									// so it can't be used to detect whether the user configured this field.
+									"severity_at_least": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      -1,
+										ValidateFunc: validation.IntBetween(-1, 4),
+									},
+									"dimension_match": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 				Set: resourceArmMonitorMetricAlertActionHash,
@@ -218,11 +403,39 @@ func resourceArmMonitorMetricAlertCreateOrUpdate(d *schema.ResourceData, meta in
 	frequency := d.Get("frequency").(string)
 	windowSize := d.Get("window_size").(string)
 	criteriaRaw := d.Get("criteria").([]interface{})
+	dynamicCriteriaRaw := d.Get("dynamic_criteria").([]interface{})
+	webtestCriteriaRaw := d.Get("webtest_location_availability_criteria").([]interface{})
 	actionRaw := d.Get("action").(*schema.Set).List()
+	targetResourceType := d.Get("target_resource_type").(string)
+	targetResourceLocation := d.Get("target_resource_location").(string)
+
+	if len(criteriaRaw) == 0 && len(dynamicCriteriaRaw) == 0 && len(webtestCriteriaRaw) == 0 {
+		return fmt.Errorf("Error: one of `criteria`, `dynamic_criteria` or `webtest_location_availability_criteria` must be specified")
+	}
+
+	// dynamic_criteria is always serialized as a multi-resource criteria, so it needs the
+	// target fields just as much as the literal multi-scope case does.
+	if (len(scopesRaw) > 1 || len(dynamicCriteriaRaw) > 0) && (targetResourceType == "" || targetResourceLocation == "") {
+		return fmt.Errorf("Error: `target_resource_type` and `target_resource_location` must be specified when more than one scope is supplied, or when `dynamic_criteria` is used")
+	}
 
 	tags := d.Get("tags").(map[string]interface{})
 	expandedTags := expandTags(tags)
 
+	var criteria insights.BasicMetricAlertCriteria
+	switch {
+	case len(webtestCriteriaRaw) > 0:
+		criteria = expandMonitorMetricAlertWebtestCriteria(webtestCriteriaRaw)
+	case len(dynamicCriteriaRaw) > 0:
+		criteria = expandMonitorMetricAlertDynamicCriteria(dynamicCriteriaRaw, targetResourceType, targetResourceLocation)
+	case len(scopesRaw) > 1:
+		criteria = expandMonitorMetricAlertMultiResourceCriteria(criteriaRaw, targetResourceType, targetResourceLocation)
+	default:
+		criteria = expandMonitorMetricAlertCriteria(criteriaRaw)
+	}
+
+	criteriaFingerprint := monitorMetricAlertCriteriaFingerprint(criteriaRaw, dynamicCriteriaRaw, webtestCriteriaRaw)
+
 	parameters := insights.MetricAlertResource{
 		Location: utils.String(azureRMNormalizeLocation("Global")),
 		MetricAlertProperties: &insights.MetricAlertProperties{
@@ -233,8 +446,8 @@ func resourceArmMonitorMetricAlertCreateOrUpdate(d *schema.ResourceData, meta in
 			EvaluationFrequency: utils.String(frequency),
 			WindowSize:          utils.String(windowSize),
 			Scopes:              expandMonitorMetricAlertStringArray(scopesRaw),
-			Criteria:            expandMonitorMetricAlertCriteria(criteriaRaw),
-			Actions:             expandMonitorMetricAlertAction(actionRaw),
+			Criteria:            criteria,
+			Actions:             expandMonitorMetricAlertAction(actionRaw, criteriaFingerprint),
 		},
 		Tags: expandedTags,
 	}
@@ -288,9 +501,18 @@ func resourceArmMonitorMetricAlertRead(d *schema.ResourceData, meta interface{})
 		if err := d.Set("scopes", flattenMonitorMetricAlertStringArray(alert.Scopes)); err != nil {
 			return fmt.Errorf("Error setting `scopes`: %+v", err)
 		}
-		if err := d.Set("criteria", flattenMonitorMetricAlertCriteria(alert.Criteria)); err != nil {
+		criteria, dynamicCriteria, webtestCriteria, targetResourceType, targetResourceLocation := flattenMonitorMetricAlertCriteria(alert.Criteria)
+		if err := d.Set("criteria", criteria); err != nil {
 			return fmt.Errorf("Error setting `criteria`: %+v", err)
 		}
+		if err := d.Set("dynamic_criteria", dynamicCriteria); err != nil {
+			return fmt.Errorf("Error setting `dynamic_criteria`: %+v", err)
+		}
+		if err := d.Set("webtest_location_availability_criteria", webtestCriteria); err != nil {
+			return fmt.Errorf("Error setting `webtest_location_availability_criteria`: %+v", err)
+		}
+		d.Set("target_resource_type", targetResourceType)
+		d.Set("target_resource_location", targetResourceLocation)
 		if err := d.Set("action", flattenMonitorMetricAlertAction(alert.Actions)); err != nil {
 			return fmt.Errorf("Error setting `action`: %+v", err)
 		}
@@ -328,20 +550,25 @@ func expandMonitorMetricAlertStringArray(input []interface{}) *[]string {
 	return &result
 }
 
+func expandMonitorMetricAlertDimensions(input []interface{}) []insights.MetricDimension {
+	dimensions := make([]insights.MetricDimension, 0)
+	for _, dimension := range input {
+		dVal := dimension.(map[string]interface{})
+		dimensions = append(dimensions, insights.MetricDimension{
+			Name:     utils.String(dVal["name"].(string)),
+			Operator: utils.String(dVal["operator"].(string)),
+			Values:   expandMonitorMetricAlertStringArray(dVal["values"].([]interface{})),
+		})
+	}
+	return dimensions
+}
+
 func expandMonitorMetricAlertCriteria(input []interface{}) *insights.MetricAlertSingleResourceMultipleMetricCriteria {
 	criterias := make([]insights.MetricCriteria, 0)
 	for i, item := range input {
 		v := item.(map[string]interface{})
 
-		dimensions := make([]insights.MetricDimension, 0)
-		for _, dimension := range v["dimension"].([]interface{}) {
-			dVal := dimension.(map[string]interface{})
-			dimensions = append(dimensions, insights.MetricDimension{
-				Name:     utils.String(dVal["name"].(string)),
-				Operator: utils.String(dVal["operator"].(string)),
-				Values:   expandMonitorMetricAlertStringArray(dVal["values"].([]interface{})),
-			})
-		}
+		dimensions := expandMonitorMetricAlertDimensions(v["dimension"].([]interface{}))
 
 		criterias = append(criterias, insights.MetricCriteria{
 			Name:            utils.String(fmt.Sprintf("Metric%d", i+1)),
@@ -359,7 +586,110 @@ func expandMonitorMetricAlertCriteria(input []interface{}) *insights.MetricAlert
 	}
 }
 
-func expandMonitorMetricAlertAction(input []interface{}) *[]insights.MetricAlertAction {
+func expandMonitorMetricAlertMultiResourceCriteria(input []interface{}, targetResourceType, targetResourceLocation string) *insights.MetricAlertMultipleResourceMultipleMetricCriteria {
+	criterias := make([]insights.BasicMetricCriteria, 0)
+	for i, item := range input {
+		v := item.(map[string]interface{})
+
+		dimensions := expandMonitorMetricAlertDimensions(v["dimension"].([]interface{}))
+
+		criterias = append(criterias, insights.MetricCriteria{
+			Name:            utils.String(fmt.Sprintf("Metric%d", i+1)),
+			CriterionType:   insights.CriterionTypeStaticThresholdCriterion,
+			MetricNamespace: utils.String(v["metric_namespace"].(string)),
+			MetricName:      utils.String(v["metric_name"].(string)),
+			TimeAggregation: v["aggregation"].(string),
+			Operator:        v["operator"].(string),
+			Threshold:       utils.Float(v["threshold"].(float64)),
+			Dimensions:      &dimensions,
+		})
+	}
+	return &insights.MetricAlertMultipleResourceMultipleMetricCriteria{
+		AllOf:                  &criterias,
+		OdataType:              insights.OdataTypeMicrosoftAzureMonitorMultipleResourceMultipleMetricCriteria,
+		TargetResourceType:     utils.String(targetResourceType),
+		TargetResourceLocation: utils.String(targetResourceLocation),
+	}
+}
+
+func expandMonitorMetricAlertDynamicCriteria(input []interface{}, targetResourceType, targetResourceLocation string) *insights.MetricAlertMultipleResourceMultipleMetricCriteria {
+	criterias := make([]insights.BasicMetricCriteria, 0)
+	for i, item := range input {
+		v := item.(map[string]interface{})
+
+		dimensions := expandMonitorMetricAlertDimensions(v["dimension"].([]interface{}))
+
+		var failingPeriods *insights.DynamicThresholdFailingPeriods
+		if v["evaluation_failing_periods"] != nil {
+			if periodsRaw := v["evaluation_failing_periods"].([]interface{}); len(periodsRaw) > 0 {
+				pVal := periodsRaw[0].(map[string]interface{})
+				failingPeriods = &insights.DynamicThresholdFailingPeriods{
+					NumberOfEvaluationPeriods: utils.Float(float64(pVal["number_of_evaluation_periods"].(int))),
+					MinFailingPeriodsToAlert:  utils.Float(float64(pVal["min_failing_periods_to_alert"].(int))),
+				}
+			}
+		}
+
+		var ignoreDataBefore *date.Time
+		if v["ignore_data_before"].(string) != "" {
+			t, _ := time.Parse(time.RFC3339, v["ignore_data_before"].(string))
+			ignoreDataBefore = &date.Time{Time: t}
+		}
+
+		criterias = append(criterias, insights.DynamicMetricCriteria{
+			Name:             utils.String(fmt.Sprintf("Metric%d", i+1)),
+			CriterionType:    insights.CriterionTypeDynamicThresholdCriterion,
+			MetricNamespace:  utils.String(v["metric_namespace"].(string)),
+			MetricName:       utils.String(v["metric_name"].(string)),
+			TimeAggregation:  v["aggregation"].(string),
+			Operator:         insights.DynamicThresholdOperator(v["operator"].(string)),
+			AlertSensitivity: insights.DynamicThresholdSensitivity(v["alert_sensitivity"].(string)),
+			FailingPeriods:   failingPeriods,
+			IgnoreDataBefore: ignoreDataBefore,
+			Dimensions:       &dimensions,
+		})
+	}
+	return &insights.MetricAlertMultipleResourceMultipleMetricCriteria{
+		AllOf:                  &criterias,
+		OdataType:              insights.OdataTypeMicrosoftAzureMonitorMultipleResourceMultipleMetricCriteria,
+		TargetResourceType:     utils.String(targetResourceType),
+		TargetResourceLocation: utils.String(targetResourceLocation),
+	}
+}
+
+func expandMonitorMetricAlertWebtestCriteria(input []interface{}) *insights.WebtestLocationAvailabilityCriteria {
+	v := input[0].(map[string]interface{})
+
+	return &insights.WebtestLocationAvailabilityCriteria{
+		WebTestID:           utils.String(v["web_test_id"].(string)),
+		ComponentID:         utils.String(v["component_id"].(string)),
+		FailedLocationCount: utils.Float(v["failed_location_count"].(float64)),
+		OdataType:           insights.OdataTypeMicrosoftAzureMonitorWebtestLocationAvailabilityCriteria,
+	}
+}
+
+// monitorMetricAlertCriteriaFingerprint derives a stable, GUID-shaped identifier from the
+// configured criteria so that `notification.mutation_id` defaults to a value that's
+// recomputed from the current criteria on every create/update, letting downstream dedupe
+// engines (e.g. PagerDuty, OpsGenie) suppress duplicate alerts raised for the same criteria
+// across Terraform runs - and pick up a new fingerprint whenever the criteria actually change.
+func monitorMetricAlertCriteriaFingerprint(criteriaRaw, dynamicCriteriaRaw, webtestCriteriaRaw []interface{}) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%#v", criteriaRaw))
+	buf.WriteString(fmt.Sprintf("%#v", dynamicCriteriaRaw))
+	buf.WriteString(fmt.Sprintf("%#v", webtestCriteriaRaw))
+
+	hash := uint32(hashcode.String(buf.String()))
+	return fmt.Sprintf("%08x-0000-0000-0000-%012x", hash, hash)
+}
+
+// monitorMetricAlertSyntheticWebhookPropertyPrefix namespaces every webhook property this
+// provider synthesizes from `notification`/`action_condition`, so a user-supplied
+// `webhook_properties` entry that happens to share a plain name (e.g. `documentation`) is
+// never mistaken for one written by Terraform and silently rehomed on Read.
+const monitorMetricAlertSyntheticWebhookPropertyPrefix = "azurerm_metric_alert_"
+
+func expandMonitorMetricAlertAction(input []interface{}, criteriaFingerprint string) *[]insights.MetricAlertAction {
 	actions := make([]insights.MetricAlertAction, 0)
 	for _, item := range input {
 		v := item.(map[string]interface{})
@@ -371,6 +701,37 @@ func expandMonitorMetricAlertAction(input []interface{}) *[]insights.MetricAlert
 			}
 		}
 
+		if notificationRaw := v["notification"].([]interface{}); len(notificationRaw) > 0 {
+			notification := notificationRaw[0].(map[string]interface{})
+
+			if doc := notification["documentation_markdown"].(string); doc != "" {
+				props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"documentation"] = utils.String(doc)
+			}
+			if runbookURL := notification["runbook_url"].(string); runbookURL != "" {
+				props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"runbook_url"] = utils.String(runbookURL)
+			}
+			for labelKey, labelValue := range notification["labels"].(map[string]interface{}) {
+				props[monitorMetricAlertSyntheticWebhookPropertyPrefix+fmt.Sprintf("label_%s", labelKey)] = utils.String(labelValue.(string))
+			}
+
+			mutationID := notification["mutation_id"].(string)
+			if mutationID == "" {
+				mutationID = criteriaFingerprint
+			}
+			props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"alert_fingerprint"] = utils.String(mutationID)
+		}
+
+		if conditionRaw := v["action_condition"].([]interface{}); len(conditionRaw) > 0 {
+			condition := conditionRaw[0].(map[string]interface{})
+
+			if severity := condition["severity_at_least"].(int); severity >= 0 {
+				props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"action_condition_severity_at_least"] = utils.String(strconv.Itoa(severity))
+			}
+			for dimensionName, dimensionValue := range condition["dimension_match"].(map[string]interface{}) {
+				props[monitorMetricAlertSyntheticWebhookPropertyPrefix+fmt.Sprintf("action_condition_dimension_%s", dimensionName)] = utils.String(dimensionValue.(string))
+			}
+		}
+
 		actions = append(actions, insights.MetricAlertAction{
 			ActionGroupID:     utils.String(v["action_group_id"].(string)),
 			WebhookProperties: props,
@@ -389,54 +750,163 @@ func flattenMonitorMetricAlertStringArray(input *[]string) []interface{} {
 	return result
 }
 
-func flattenMonitorMetricAlertCriteria(input insights.BasicMetricAlertCriteria) (result []interface{}) {
-	result = make([]interface{}, 0)
+func flattenMonitorMetricAlertDimensions(input *[]insights.MetricDimension) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
 	if input == nil {
-		return
+		return result
+	}
+	for _, dimension := range *input {
+		dVal := make(map[string]interface{})
+		if dimension.Name != nil {
+			dVal["name"] = *dimension.Name
+		}
+		if dimension.Operator != nil {
+			dVal["operator"] = *dimension.Operator
+		}
+		dVal["values"] = flattenMonitorMetricAlertStringArray(dimension.Values)
+		result = append(result, dVal)
 	}
-	criteria, ok := input.AsMetricAlertSingleResourceMultipleMetricCriteria()
-	if !ok || criteria == nil || criteria.AllOf == nil {
+	return result
+}
+
+func flattenMonitorMetricAlertCriteria(input insights.BasicMetricAlertCriteria) (staticCriteria []interface{}, dynamicCriteria []interface{}, webtestCriteria []interface{}, targetResourceType string, targetResourceLocation string) {
+	staticCriteria = make([]interface{}, 0)
+	dynamicCriteria = make([]interface{}, 0)
+	webtestCriteria = make([]interface{}, 0)
+	if input == nil {
 		return
 	}
-	for _, metric := range *criteria.AllOf {
-		v := make(map[string]interface{})
 
-		if metric.MetricNamespace != nil {
-			v["metric_namespace"] = *metric.MetricNamespace
+	if criteria, ok := input.AsWebtestLocationAvailabilityCriteria(); ok && criteria != nil {
+		v := make(map[string]interface{})
+		if criteria.WebTestID != nil {
+			v["web_test_id"] = *criteria.WebTestID
 		}
-		if metric.MetricName != nil {
-			v["metric_name"] = *metric.MetricName
+		if criteria.ComponentID != nil {
+			v["component_id"] = *criteria.ComponentID
 		}
-		if aggr, ok := metric.TimeAggregation.(string); ok {
-			v["aggregation"] = aggr
+		if criteria.FailedLocationCount != nil {
+			v["failed_location_count"] = *criteria.FailedLocationCount
 		}
-		if op, ok := metric.Operator.(string); ok {
-			v["operator"] = op
+		webtestCriteria = append(webtestCriteria, v)
+		return
+	}
+
+	if criteria, ok := input.AsMetricAlertSingleResourceMultipleMetricCriteria(); ok && criteria != nil && criteria.AllOf != nil {
+		for _, metric := range *criteria.AllOf {
+			v := make(map[string]interface{})
+
+			if metric.MetricNamespace != nil {
+				v["metric_namespace"] = *metric.MetricNamespace
+			}
+			if metric.MetricName != nil {
+				v["metric_name"] = *metric.MetricName
+			}
+			if aggr, ok := metric.TimeAggregation.(string); ok {
+				v["aggregation"] = aggr
+			}
+			if op, ok := metric.Operator.(string); ok {
+				v["operator"] = op
+			}
+			if metric.Threshold != nil {
+				v["threshold"] = *metric.Threshold
+			}
+			v["dimension"] = flattenMonitorMetricAlertDimensions(metric.Dimensions)
+
+			staticCriteria = append(staticCriteria, v)
+		}
+		return
+	}
+
+	if criteria, ok := input.AsMetricAlertMultipleResourceMultipleMetricCriteria(); ok && criteria != nil && criteria.AllOf != nil {
+		if criteria.TargetResourceType != nil {
+			targetResourceType = *criteria.TargetResourceType
 		}
-		if metric.Threshold != nil {
-			v["threshold"] = *metric.Threshold
+		if criteria.TargetResourceLocation != nil {
+			targetResourceLocation = *criteria.TargetResourceLocation
 		}
-		if metric.Dimensions != nil {
-			dimResult := make([]map[string]interface{}, 0)
-			for _, dimension := range *metric.Dimensions {
-				dVal := make(map[string]interface{})
-				if dimension.Name != nil {
-					dVal["name"] = *dimension.Name
+
+		for _, basicMetric := range *criteria.AllOf {
+			if metric, ok := basicMetric.AsMetricCriteria(); ok && metric != nil {
+				v := make(map[string]interface{})
+
+				if metric.MetricNamespace != nil {
+					v["metric_namespace"] = *metric.MetricNamespace
+				}
+				if metric.MetricName != nil {
+					v["metric_name"] = *metric.MetricName
+				}
+				if aggr, ok := metric.TimeAggregation.(string); ok {
+					v["aggregation"] = aggr
 				}
-				if dimension.Operator != nil {
-					dVal["operator"] = *dimension.Operator
+				if op, ok := metric.Operator.(string); ok {
+					v["operator"] = op
 				}
-				dVal["values"] = flattenMonitorMetricAlertStringArray(dimension.Values)
-				dimResult = append(dimResult, dVal)
+				if metric.Threshold != nil {
+					v["threshold"] = *metric.Threshold
+				}
+				v["dimension"] = flattenMonitorMetricAlertDimensions(metric.Dimensions)
+
+				staticCriteria = append(staticCriteria, v)
+				continue
 			}
-			v["dimension"] = dimResult
-		}
 
-		result = append(result, v)
+			if metric, ok := basicMetric.AsDynamicMetricCriteria(); ok && metric != nil {
+				v := make(map[string]interface{})
+
+				if metric.MetricNamespace != nil {
+					v["metric_namespace"] = *metric.MetricNamespace
+				}
+				if metric.MetricName != nil {
+					v["metric_name"] = *metric.MetricName
+				}
+				if aggr, ok := metric.TimeAggregation.(string); ok {
+					v["aggregation"] = aggr
+				}
+				v["operator"] = string(metric.Operator)
+				v["alert_sensitivity"] = string(metric.AlertSensitivity)
+				if metric.FailingPeriods != nil {
+					periods := make([]interface{}, 0)
+					period := make(map[string]interface{})
+					if metric.FailingPeriods.NumberOfEvaluationPeriods != nil {
+						period["number_of_evaluation_periods"] = int(*metric.FailingPeriods.NumberOfEvaluationPeriods)
+					}
+					if metric.FailingPeriods.MinFailingPeriodsToAlert != nil {
+						period["min_failing_periods_to_alert"] = int(*metric.FailingPeriods.MinFailingPeriodsToAlert)
+					}
+					periods = append(periods, period)
+					v["evaluation_failing_periods"] = periods
+				}
+				if metric.IgnoreDataBefore != nil {
+					v["ignore_data_before"] = metric.IgnoreDataBefore.Format(time.RFC3339)
+				}
+				v["dimension"] = flattenMonitorMetricAlertDimensions(metric.Dimensions)
+
+				dynamicCriteria = append(dynamicCriteria, v)
+			}
+		}
 	}
+
 	return
 }
 
+// reservedMonitorMetricAlertWebhookProperties are the webhook properties the provider
+// synthesizes from `notification` and `action_condition` on write - they're stripped back
+// out of `webhook_properties` on read so that they only ever appear in their own blocks
+// (`alert_fingerprint` is not read back into any block, see `mutation_id`). All
+// of them carry the monitorMetricAlertSyntheticWebhookPropertyPrefix, so a user-supplied
+// `webhook_properties` entry using one of these plain names is never mistaken for one of ours.
+var reservedMonitorMetricAlertWebhookPropertyPrefixes = []string{
+	monitorMetricAlertSyntheticWebhookPropertyPrefix + "label_",
+	monitorMetricAlertSyntheticWebhookPropertyPrefix + "action_condition_dimension_",
+}
+var reservedMonitorMetricAlertWebhookProperties = []string{
+	monitorMetricAlertSyntheticWebhookPropertyPrefix + "documentation",
+	monitorMetricAlertSyntheticWebhookPropertyPrefix + "runbook_url",
+	monitorMetricAlertSyntheticWebhookPropertyPrefix + "alert_fingerprint",
+	monitorMetricAlertSyntheticWebhookPropertyPrefix + "action_condition_severity_at_least",
+}
+
 func flattenMonitorMetricAlertAction(input *[]insights.MetricAlertAction) []interface{} {
 	result := make([]interface{}, 0)
 	if input != nil {
@@ -453,6 +923,66 @@ func flattenMonitorMetricAlertAction(input *[]insights.MetricAlertAction) []inte
 					props[pk] = *pv
 				}
 			}
+
+			notification := make(map[string]interface{})
+			if doc, ok := props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"documentation"]; ok {
+				notification["documentation_markdown"] = doc
+			}
+			if runbookURL, ok := props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"runbook_url"]; ok {
+				notification["runbook_url"] = runbookURL
+			}
+			// mutation_id is deliberately NOT populated from `alert_fingerprint` here - see the
+			// schema comment on `mutation_id` for why round-tripping it through state breaks the
+			// criteria-fingerprint fallback in expandMonitorMetricAlertAction.
+			labels := make(map[string]interface{})
+			labelPrefix := monitorMetricAlertSyntheticWebhookPropertyPrefix + "label_"
+			for pk, pv := range props {
+				if strings.HasPrefix(pk, labelPrefix) {
+					labels[strings.TrimPrefix(pk, labelPrefix)] = pv
+				}
+			}
+			if len(labels) > 0 {
+				notification["labels"] = labels
+			}
+			if len(notification) > 0 {
+				v["notification"] = []interface{}{notification}
+			}
+
+			condition := make(map[string]interface{})
+			if severity, ok := props[monitorMetricAlertSyntheticWebhookPropertyPrefix+"action_condition_severity_at_least"]; ok {
+				if i, err := strconv.Atoi(severity); err == nil {
+					condition["severity_at_least"] = i
+				}
+			}
+			dimensionMatch := make(map[string]interface{})
+			dimensionPrefix := monitorMetricAlertSyntheticWebhookPropertyPrefix + "action_condition_dimension_"
+			for pk, pv := range props {
+				if strings.HasPrefix(pk, dimensionPrefix) {
+					dimensionMatch[strings.TrimPrefix(pk, dimensionPrefix)] = pv
+				}
+			}
+			if len(dimensionMatch) > 0 {
+				condition["dimension_match"] = dimensionMatch
+			}
+			if len(condition) > 0 {
+				// no severity property means the -1 "not set" default, not 0 (Critical)
+				if _, ok := condition["severity_at_least"]; !ok {
+					condition["severity_at_least"] = -1
+				}
+				v["action_condition"] = []interface{}{condition}
+			}
+
+			for _, reserved := range reservedMonitorMetricAlertWebhookProperties {
+				delete(props, reserved)
+			}
+			for pk := range props {
+				for _, prefix := range reservedMonitorMetricAlertWebhookPropertyPrefixes {
+					if strings.HasPrefix(pk, prefix) {
+						delete(props, pk)
+						break
+					}
+				}
+			}
 			v["webhook_properties"] = props
 
 			result = append(result, v)
@@ -465,6 +995,37 @@ func resourceArmMonitorMetricAlertActionHash(input interface{}) int {
 	var buf bytes.Buffer
 	if v, ok := input.(map[string]interface{}); ok {
 		buf.WriteString(fmt.Sprintf("%s-", v["action_group_id"].(string)))
+
+		if notificationRaw, ok := v["notification"].([]interface{}); ok && len(notificationRaw) > 0 {
+			notification := notificationRaw[0].(map[string]interface{})
+			// mutation_id is a plain passthrough of the configured value (never read back from
+			// the server - see flattenMonitorMetricAlertAction), so unlike the rest of this
+			// resource's Computed fields it's known at plan time and safe to include here.
+			buf.WriteString(fmt.Sprintf("%s-%s-%s-", notification["documentation_markdown"].(string), notification["runbook_url"].(string), notification["mutation_id"].(string)))
+			buf.WriteString(hashMonitorMetricAlertStringMap(notification["labels"].(map[string]interface{})))
+		}
+
+		if conditionRaw, ok := v["action_condition"].([]interface{}); ok && len(conditionRaw) > 0 {
+			condition := conditionRaw[0].(map[string]interface{})
+			buf.WriteString(fmt.Sprintf("%d-", condition["severity_at_least"].(int)))
+			buf.WriteString(hashMonitorMetricAlertStringMap(condition["dimension_match"].(map[string]interface{})))
+		}
 	}
 	return hashcode.String(buf.String())
 }
+
+// hashMonitorMetricAlertStringMap renders a map deterministically (sorted by key) so that
+// Set hashes derived from it are stable regardless of Go's randomized map iteration order.
+func hashMonitorMetricAlertStringMap(input map[string]interface{}) string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString(fmt.Sprintf("%s:%s-", k, input[k].(string)))
+	}
+	return buf.String()
+}